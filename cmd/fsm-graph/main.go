@@ -0,0 +1,43 @@
+// Command fsm-graph prints a fsm.StateMachine as a Mermaid or
+// Graphviz DOT diagram, so it can be wired into a `go generate` step
+// to keep flow docs in sync with the code.
+//
+// The StateMachine itself isn't known to this binary; register it by
+// building with -tags fsmgraph_machine against your own file that
+// implements Machine() the way registry.go's default does.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mindstand/fsm/stateparser"
+)
+
+func main() {
+	format := flag.String("format", "mermaid", "diagram format: mermaid or dot")
+	flag.Parse()
+
+	sm := Machine()
+
+	var (
+		out string
+		err error
+	)
+	switch *format {
+	case "mermaid":
+		out, err = stateparser.RenderMermaid(sm)
+	case "dot":
+		out, err = stateparser.RenderDOT(sm)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %q, want mermaid or dot\n", *format)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to render diagram: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(out)
+}
@@ -0,0 +1,12 @@
+//go:build !fsmgraph_machine
+
+package main
+
+import "github.com/mindstand/fsm"
+
+// Machine returns the StateMachine to diagram. This default build
+// panics; supply your own Machine() in a file built with
+// -tags fsmgraph_machine to register the real thing.
+func Machine() fsm.StateMachine {
+	panic("fsm-graph: no StateMachine registered; build with -tags fsmgraph_machine and provide your own Machine()")
+}
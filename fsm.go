@@ -1,6 +1,9 @@
 package fsm
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // StartState is a constant for defining the slug of
 // the start state for all StateMachines.
@@ -18,11 +21,51 @@ type StateMap map[string]BuildState
 // with access to a specific Emitter and Traverser
 type BuildState func(Emitter, Traverser) *State
 
+// Policy declares the constraints a transition is checked against:
+// ForcedStates that are always reachable regardless of Transitions,
+// and an optional declarative Transitions table. It's passed
+// explicitly alongside a StateMachine/StateMap to Validate, Step, and
+// TriggerState rather than living in package-level vars, so multiple
+// machines - or one machine reconfigured while traversers are still
+// moving through it - don't stomp on each other.
+type Policy struct {
+	// ForcedStates are slugs that are always reachable from any
+	// other state, regardless of what Transitions declares. Meant
+	// for sink states such as "error" or "canceled" that a
+	// StateMachine must be able to drop into no matter where a
+	// traverser currently is.
+	ForcedStates map[string]struct{}
+
+	// Transitions is an optional declarative transition table, keyed
+	// by source state slug and mapping to the set of target slugs
+	// permitted from it. A nil Transitions leaves every transition
+	// unrestricted. Use Validate to check a table against a
+	// StateMachine before relying on it.
+	Transitions map[string]map[string]struct{}
+}
+
 // State represents an individual state in a larger state machine
 type State struct {
 	Slug         string
 	IsExitable bool
 	Entry        func(isReentry bool) error
+	// EntryFunc is the context-aware successor to Entry: when set, it's
+	// called instead, so long-running Entry actions (an LLM call, say)
+	// can observe ctx.Done() and return promptly. Entry is still called
+	// as a fallback for states that haven't migrated yet.
+	EntryFunc    func(ctx context.Context, isReentry bool) error
+	Exit         func() error
+	// OnTransition fires between the outgoing state's Exit and this
+	// state's Entry, regardless of which intent triggered the move,
+	// so it lives here on the arriving State rather than on Intent.
+	// Guard (see Candidate, in state_builder.go) instead needs to
+	// discriminate by intent; rather than adding a Guard field to
+	// Intent itself - which this package only ever sees as an opaque
+	// *Intent, never defined in this tree - Candidate carries its own
+	// optional *Intent matched by Name, so PermitIf/PermitReentry can
+	// gate a target on a specific intent without this package needing
+	// to own Intent's shape.
+	OnTransition func(ctx context.Context, traverser Traverser, params map[string]string) error
 	ValidIntents func() []*Intent
 	Transition   func(*Intent, map[string]string) *State
 }
@@ -40,6 +83,17 @@ type Store interface {
 	CreateTraverser(uuid string) (Traverser, error)
 }
 
+// StoreCtx is an optional extension of Store for implementations that
+// can observe context cancellation while fetching or creating a
+// traverser (e.g. an I/O call to a database). StepContext and
+// TriggerStateContext use it when a Store implements it, and fall
+// back to the plain Store methods otherwise, so existing Stores keep
+// working unmodified.
+type StoreCtx interface {
+	FetchTraverserCtx(ctx context.Context, uuid string) (Traverser, error)
+	CreateTraverserCtx(ctx context.Context, uuid string) (Traverser, error)
+}
+
 // A Traverser is an individual that is traversing the
 // StateMachine.  This interface that is responsible
 // for managing the state of that individual
@@ -58,10 +112,22 @@ type Traverser interface {
 	CurrentState() (string, error)
 	SetCurrentState(string) error
 
+	// Clock is a monotonic counter that increments every time
+	// SetCurrentState is called, so callers can detect whether a
+	// traverser's state actually moved underneath them.
+	Clock() (uint64, error)
+
 	// Queue
-	// Note invoking queued states must be done manually
 	AddQueuedState(state string, info interface{}) error
 	DequeueQueuedState() error
+	// PeekQueuedState returns the next queued state without removing
+	// it, or an empty state if nothing is queued.
+	PeekQueuedState() (state string, info interface{}, err error)
+	// QueuedStateDepth returns the number of states currently queued,
+	// so callers (TriggerState, in particular, which reports it as
+	// EventQueued's Depth for MetricsObserver) can report how backed
+	// up a traverser's queue is without having to drain it.
+	QueuedStateDepth() (int, error)
 
 	// Data
 	Upsert(key string, value interface{}) error
@@ -0,0 +1,113 @@
+package fsm
+
+import (
+	"context"
+	"sync"
+)
+
+// Locker is an optional interface a Store can implement to serialize
+// concurrent Step/TriggerState calls for the same traverser, so two
+// requests for the same uuid arriving close together can't race each
+// other. When a Store doesn't implement Locker, an in-memory locker
+// keyed by uuid is used instead, which only serializes calls within
+// this process.
+//
+// A distributed deployment should implement Locker on its Store
+// instead (e.g. backed by Redis, taking the lock with
+// SET uuid processing NX PX <ttl> and releasing with DEL), so every
+// process serializes on the same key.
+type Locker interface {
+	// LockContext acquires the lock for uuid, blocking until it's
+	// free. It returns ctx.Err() promptly if ctx is canceled while
+	// waiting, instead of blocking uninterruptibly through however
+	// long the current holder's Step/TriggerState takes.
+	LockContext(ctx context.Context, uuid string) error
+	Unlock(uuid string) error
+}
+
+// lockHandle is a single uuid's lock token plus a count of callers
+// currently waiting on or holding it, so mapLocker knows when it's
+// safe to evict the entry.
+type lockHandle struct {
+	ch   chan struct{}
+	refs int
+}
+
+// mapLocker is the default, in-memory Locker used when a Store
+// doesn't implement one itself. Entries are refcounted and removed
+// from locks as soon as nothing is waiting on or holding them, so a
+// long-lived process doesn't accumulate one entry per distinct uuid
+// it has ever seen.
+type mapLocker struct {
+	mu    sync.Mutex
+	locks map[string]*lockHandle
+}
+
+func newMapLocker() *mapLocker {
+	return &mapLocker{locks: make(map[string]*lockHandle)}
+}
+
+// acquireHandle returns uuid's lockHandle, creating it if needed, and
+// registers the caller against it so release knows not to evict it
+// out from under a waiter.
+func (l *mapLocker) acquireHandle(uuid string) *lockHandle {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.locks[uuid]
+	if !ok {
+		h = &lockHandle{ch: make(chan struct{}, 1)}
+		h.ch <- struct{}{}
+		l.locks[uuid] = h
+	}
+	h.refs++
+	return h
+}
+
+// release drops the caller's reference to h, evicting uuid from locks
+// once nothing else is waiting on or holding it.
+func (l *mapLocker) release(uuid string, h *lockHandle) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h.refs--
+	if h.refs == 0 {
+		delete(l.locks, uuid)
+	}
+}
+
+func (l *mapLocker) LockContext(ctx context.Context, uuid string) error {
+	h := l.acquireHandle(uuid)
+
+	select {
+	case <-h.ch:
+		return nil
+	case <-ctx.Done():
+		l.release(uuid, h)
+		return ctx.Err()
+	}
+}
+
+func (l *mapLocker) Unlock(uuid string) error {
+	l.mu.Lock()
+	h, ok := l.locks[uuid]
+	l.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	h.ch <- struct{}{}
+	l.release(uuid, h)
+	return nil
+}
+
+var defaultLocker = newMapLocker()
+
+// lockerFor returns store's Locker if it implements one, falling back
+// to the process-wide in-memory default otherwise.
+func lockerFor(store Store) Locker {
+	if locker, ok := store.(Locker); ok {
+		return locker
+	}
+	return defaultLocker
+}
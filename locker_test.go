@@ -0,0 +1,75 @@
+package fsm
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMapLockerSerializesPerUUID(t *testing.T) {
+	l := newMapLocker()
+
+	if err := l.LockContext(context.Background(), "a"); err != nil {
+		t.Fatalf("failed to acquire lock, %v", err)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := l.LockContext(context.Background(), "a"); err != nil {
+			t.Errorf("failed to acquire lock, %v", err)
+		}
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second LockContext acquired while first holder still held the lock")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := l.Unlock("a"); err != nil {
+		t.Fatalf("failed to unlock, %v", err)
+	}
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("second LockContext never acquired after first holder unlocked")
+	}
+
+	if err := l.Unlock("a"); err != nil {
+		t.Fatalf("failed to unlock, %v", err)
+	}
+}
+
+func TestMapLockerLockContextCanceled(t *testing.T) {
+	l := newMapLocker()
+
+	if err := l.LockContext(context.Background(), "a"); err != nil {
+		t.Fatalf("failed to acquire lock, %v", err)
+	}
+	defer l.Unlock("a")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := l.LockContext(ctx, "a")
+	if err == nil {
+		t.Fatal("expected LockContext to return an error once ctx was canceled")
+	}
+}
+
+func TestMapLockerEvictsIdleEntries(t *testing.T) {
+	l := newMapLocker()
+
+	if err := l.LockContext(context.Background(), "a"); err != nil {
+		t.Fatalf("failed to acquire lock, %v", err)
+	}
+	if err := l.Unlock("a"); err != nil {
+		t.Fatalf("failed to unlock, %v", err)
+	}
+
+	if len(l.locks) != 0 {
+		t.Fatalf("expected locks to be empty after the only holder unlocked, got %d entries", len(l.locks))
+	}
+}
@@ -0,0 +1,100 @@
+package fsm
+
+import "time"
+
+// EventType identifies the kind of lifecycle event a NotificationEvent
+// describes.
+type EventType string
+
+const (
+	// EventEntry fires when a state's Entry action runs for the first time.
+	EventEntry EventType = "entry"
+	// EventReentry fires when a traverser lands back on a state it was
+	// already in, including the recursive re-entry performEntryAction
+	// does when an Entry action shifts state on its own.
+	EventReentry EventType = "reentry"
+	// EventTransition fires when a traverser successfully moves from
+	// one state to another.
+	EventTransition EventType = "transition"
+	// EventQueued fires when TriggerState can't act immediately and
+	// enqueues the target state instead.
+	EventQueued EventType = "queued"
+	// EventTimeoutExpired fires when a stuck traverser is aborted past
+	// InputTimeout.
+	EventTimeoutExpired EventType = "timeout_expired"
+	// EventError fires in place of any of the above when the step that
+	// would have produced it failed, and when a StateBuilder Guard
+	// returns an error.
+	EventError EventType = "error"
+)
+
+// NotificationEvent describes a single lifecycle event raised while a
+// traverser moves through a StateMachine.
+type NotificationEvent struct {
+	Timestamp   time.Time
+	UUID        string
+	Platform    string
+	SourceState string
+	TargetState string
+	EventType   EventType
+	Error       error
+
+	// Duration is how long the Entry/EntryFunc call that produced an
+	// EventEntry or EventReentry took, or that failed and produced an
+	// EventError. Zero for every other event.
+	Duration time.Duration
+
+	// Depth is the traverser's queued-state depth at the time an
+	// EventQueued was fired, from Traverser.QueuedStateDepth. Zero for
+	// every other EventType.
+	Depth int
+}
+
+// Observer receives NotificationEvents as traversers move through a
+// StateMachine. Notify is called synchronously from Step and
+// TriggerState, so an Observer that does expensive work should hand
+// off to a goroutine or buffered channel itself rather than block the
+// caller.
+type Observer interface {
+	Notify(NotificationEvent)
+}
+
+// observers is the process-wide set of registered Observers. Step and
+// TriggerState fire every NotificationEvent to each of them.
+var observers []Observer
+
+// RegisterObserver adds an Observer that will receive every
+// NotificationEvent fired by Step and TriggerState from this point
+// on. Typically called once at startup.
+func RegisterObserver(o Observer) {
+	observers = append(observers, o)
+}
+
+// notify stamps event with the current time and fires it to every
+// registered Observer.
+func notify(event NotificationEvent) {
+	if len(observers) == 0 {
+		return
+	}
+	event.Timestamp = time.Now().UTC()
+	for _, o := range observers {
+		o.Notify(event)
+	}
+}
+
+// notifyTraverser fills in event's UUID and Platform from traverser
+// before firing it. It's a best-effort lookup: a traverser that fails
+// to report its identity still results in the event being fired, just
+// without those fields populated.
+func notifyTraverser(traverser Traverser, event NotificationEvent) {
+	if len(observers) == 0 {
+		return
+	}
+	if uuid, err := traverser.UUID(); err == nil {
+		event.UUID = uuid
+	}
+	if platform, err := traverser.Platform(); err == nil {
+		event.Platform = platform
+	}
+	notify(event)
+}
@@ -0,0 +1,32 @@
+package fsm
+
+import "log"
+
+// LoggingObserver is a built-in Observer that writes every
+// NotificationEvent to a *log.Logger. It's meant as a drop-in default
+// so operators get basic visibility without writing their own
+// Observer first.
+type LoggingObserver struct {
+	Logger *log.Logger
+}
+
+// NewLoggingObserver returns a LoggingObserver that writes to logger.
+// A nil logger falls back to log.Default().
+func NewLoggingObserver(logger *log.Logger) *LoggingObserver {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &LoggingObserver{Logger: logger}
+}
+
+// Notify implements Observer.
+func (o *LoggingObserver) Notify(event NotificationEvent) {
+	if event.Error != nil {
+		o.Logger.Printf("fsm: %s uuid=%s platform=%s %s -> %s: %v",
+			event.EventType, event.UUID, event.Platform, event.SourceState, event.TargetState, event.Error)
+		return
+	}
+
+	o.Logger.Printf("fsm: %s uuid=%s platform=%s %s -> %s",
+		event.EventType, event.UUID, event.Platform, event.SourceState, event.TargetState)
+}
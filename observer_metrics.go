@@ -0,0 +1,119 @@
+package fsm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// lastSeenRetention bounds how long MetricsObserver remembers a
+// uuid's last event before treating it as idle and eligible for
+// eviction.
+const lastSeenRetention = 30 * time.Minute
+
+// lastSeenSweepInterval is how many Notify calls MetricsObserver lets
+// pass between sweeps of lastSeen for idle entries.
+const lastSeenSweepInterval = 256
+
+// MetricsObserver is a built-in Observer that records Prometheus
+// metrics for transitions per state, queued states, errors, entry
+// action latency, queue depth, and the gap between consecutive
+// lifecycle events for a traverser.
+type MetricsObserver struct {
+	transitions  *prometheus.CounterVec
+	queued       *prometheus.CounterVec
+	errors       *prometheus.CounterVec
+	gap          *prometheus.HistogramVec
+	entryLatency *prometheus.HistogramVec
+	queueDepth   *prometheus.GaugeVec
+
+	mu       sync.Mutex
+	lastSeen map[string]time.Time
+	calls    uint64
+}
+
+// NewMetricsObserver registers its collectors with reg and returns a
+// ready-to-use MetricsObserver.
+func NewMetricsObserver(reg prometheus.Registerer) *MetricsObserver {
+	o := &MetricsObserver{
+		transitions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "fsm",
+			Name:      "transitions_total",
+			Help:      "Number of successful transitions, by source and target state.",
+		}, []string{"source_state", "target_state"}),
+		queued: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "fsm",
+			Name:      "queued_total",
+			Help:      "Number of times a target state was queued instead of entered immediately.",
+		}, []string{"target_state"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "fsm",
+			Name:      "errors_total",
+			Help:      "Number of errors raised while processing a state, by source state.",
+		}, []string{"source_state"}),
+		gap: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "fsm",
+			Name:      "lifecycle_gap_seconds",
+			Help:      "Time between consecutive lifecycle events observed for a traverser (not entry-action duration).",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"target_state"}),
+		entryLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "fsm",
+			Name:      "entry_action_seconds",
+			Help:      "How long a state's Entry/EntryFunc call took, by the state entered.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"target_state"}),
+		queueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "fsm",
+			Name:      "queue_depth",
+			Help:      "Traverser queued-state depth at the time a state was queued, by the state queued.",
+		}, []string{"target_state"}),
+		lastSeen: make(map[string]time.Time),
+	}
+
+	reg.MustRegister(o.transitions, o.queued, o.errors, o.gap, o.entryLatency, o.queueDepth)
+	return o
+}
+
+// Notify implements Observer.
+func (o *MetricsObserver) Notify(event NotificationEvent) {
+	switch event.EventType {
+	case EventTransition:
+		o.transitions.WithLabelValues(event.SourceState, event.TargetState).Inc()
+	case EventEntry, EventReentry:
+		o.entryLatency.WithLabelValues(event.TargetState).Observe(event.Duration.Seconds())
+	case EventQueued:
+		o.queued.WithLabelValues(event.TargetState).Inc()
+		o.queueDepth.WithLabelValues(event.TargetState).Set(float64(event.Depth))
+	case EventError:
+		o.errors.WithLabelValues(event.SourceState).Inc()
+	}
+
+	if event.UUID == "" {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if last, ok := o.lastSeen[event.UUID]; ok {
+		o.gap.WithLabelValues(event.TargetState).Observe(event.Timestamp.Sub(last).Seconds())
+	}
+	o.lastSeen[event.UUID] = event.Timestamp
+
+	o.calls++
+	if o.calls%lastSeenSweepInterval == 0 {
+		o.sweepLastSeenLocked(event.Timestamp)
+	}
+}
+
+// sweepLastSeenLocked removes lastSeen entries older than
+// lastSeenRetention, bounding the map's growth for uuids that have
+// stopped producing events. Callers must hold o.mu.
+func (o *MetricsObserver) sweepLastSeenLocked(now time.Time) {
+	for uuid, last := range o.lastSeen {
+		if now.Sub(last) > lastSeenRetention {
+			delete(o.lastSeen, uuid)
+		}
+	}
+}
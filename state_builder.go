@@ -0,0 +1,111 @@
+package fsm
+
+import "fmt"
+
+// Candidate is one possible target for a transition, gated by an
+// optional Intent match and an optional Guard. A nil Intent matches
+// any incoming intent, so a Candidate gated purely by Guard/params can
+// leave it unset. A nil Guard always passes once Intent matches.
+type Candidate struct {
+	Intent *Intent
+	Target *State
+	Guard  func(traverser Traverser, params map[string]string) (bool, error)
+}
+
+// matches reports whether candidate applies to intent: a nil
+// c.Intent matches anything, otherwise intent must be non-nil and
+// share its Name.
+func (c Candidate) matches(intent *Intent) bool {
+	if c.Intent == nil {
+		return true
+	}
+	return intent != nil && intent.Name == c.Intent.Name
+}
+
+// StateBuilder assembles a Transition func from a declaration-ordered
+// list of Candidates, mirroring the trigger/guard model of mature FSM
+// libraries so callers don't have to hand-write the evaluation loop
+// themselves. The resulting Transition evaluates, in order, every
+// Candidate whose Intent matches the one that fired, and returns the
+// Target of the first one whose Guard also passes (or that has no
+// Guard at all); if none match, the transition is a no-op, same as a
+// Transition func returning nil.
+type StateBuilder struct {
+	traverser  Traverser
+	candidates []Candidate
+}
+
+// NewStateBuilder starts a StateBuilder bound to traverser, which is
+// passed to every Guard it evaluates.
+func NewStateBuilder(traverser Traverser) *StateBuilder {
+	return &StateBuilder{traverser: traverser}
+}
+
+// PermitIf adds target as a candidate, reachable only when intent
+// matches the incoming intent (by Name) and guard returns true. A nil
+// intent makes the candidate match any incoming intent.
+func (b *StateBuilder) PermitIf(intent *Intent, target *State, guard func(traverser Traverser, params map[string]string) (bool, error)) *StateBuilder {
+	b.candidates = append(b.candidates, Candidate{Intent: intent, Target: target, Guard: guard})
+	return b
+}
+
+// PermitReentry adds target as an unconditional candidate for intent
+// (or for any intent, if intent is nil): once its Intent matches, it
+// always wins in declaration order, matching a Transition that simply
+// returns its target with no guard.
+func (b *StateBuilder) PermitReentry(intent *Intent, target *State) *StateBuilder {
+	b.candidates = append(b.candidates, Candidate{Intent: intent, Target: target})
+	return b
+}
+
+// Build returns a Transition func suitable for State.Transition.
+func (b *StateBuilder) Build() func(*Intent, map[string]string) *State {
+	candidates := b.candidates
+	traverser := b.traverser
+
+	return func(intent *Intent, params map[string]string) *State {
+		for _, candidate := range candidates {
+			if !candidate.matches(intent) {
+				continue
+			}
+
+			if candidate.Guard == nil {
+				return candidate.Target
+			}
+
+			ok, err := candidate.Guard(traverser, params)
+			if err != nil {
+				notifyGuardError(traverser, candidate.Target, err)
+				continue
+			}
+			if !ok {
+				continue
+			}
+
+			return candidate.Target
+		}
+
+		return nil
+	}
+}
+
+// notifyGuardError fires an EventError notification when a Guard
+// returns an error, so a failing precondition is surfaced to
+// registered Observers rather than silently treated the same as a
+// candidate that simply didn't match.
+//
+// Build's Transition func can't itself return an error (its signature
+// is fixed by State.Transition), so this is the only channel a guard
+// error has: with no Observer registered, notifyTraverser is a no-op
+// and the error is invisible. Register an Observer (e.g.
+// LoggingObserver) in any StateMachine that uses PermitIf with a
+// fallible Guard.
+func notifyGuardError(traverser Traverser, target *State, err error) {
+	sourceState, _ := traverser.CurrentState()
+	notifyTraverser(traverser, NotificationEvent{
+		SourceState: sourceState,
+		TargetState: target.Slug,
+		EventType:   EventError,
+		Error:       fmt.Errorf("guard for candidate (%s) failed, %w", target.Slug, err),
+	})
+}
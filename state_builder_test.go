@@ -0,0 +1,60 @@
+package fsm
+
+import "testing"
+
+type stubTraverser struct {
+	Traverser
+	currentState string
+}
+
+func (s *stubTraverser) CurrentState() (string, error) {
+	return s.currentState, nil
+}
+
+func TestStateBuilderDiscriminatesByIntent(t *testing.T) {
+	traverser := &stubTraverser{currentState: "start"}
+	approve := &Intent{Name: "approve"}
+	reject := &Intent{Name: "reject"}
+
+	approved := &State{Slug: "approved"}
+	rejected := &State{Slug: "rejected"}
+
+	transition := NewStateBuilder(traverser).
+		PermitReentry(approve, approved).
+		PermitReentry(reject, rejected).
+		Build()
+
+	if got := transition(approve, nil); got != approved {
+		t.Fatalf("expected approve intent to reach approved, got %v", got)
+	}
+	if got := transition(reject, nil); got != rejected {
+		t.Fatalf("expected reject intent to reach rejected, got %v", got)
+	}
+}
+
+func TestStateBuilderNilIntentCandidateMatchesAny(t *testing.T) {
+	traverser := &stubTraverser{currentState: "start"}
+	anyTarget := &State{Slug: "anywhere"}
+
+	transition := NewStateBuilder(traverser).
+		PermitReentry(nil, anyTarget).
+		Build()
+
+	if got := transition(&Intent{Name: "whatever"}, nil); got != anyTarget {
+		t.Fatalf("expected nil-intent candidate to match any incoming intent, got %v", got)
+	}
+}
+
+func TestStateBuilderNoMatchingIntentIsNoop(t *testing.T) {
+	traverser := &stubTraverser{currentState: "start"}
+	approve := &Intent{Name: "approve"}
+	approved := &State{Slug: "approved"}
+
+	transition := NewStateBuilder(traverser).
+		PermitReentry(approve, approved).
+		Build()
+
+	if got := transition(&Intent{Name: "reject"}, nil); got != nil {
+		t.Fatalf("expected no candidate to match, got %v", got)
+	}
+}
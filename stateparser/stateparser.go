@@ -0,0 +1,156 @@
+// Package stateparser renders a fsm.StateMachine as a diagram, either
+// Mermaid or Graphviz DOT, so a flow can be documented without
+// manually tracing through every BuildState.
+package stateparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mindstand/fsm"
+)
+
+// edge is a single outgoing transition discovered for a state.
+type edge struct {
+	intent  string
+	target  string
+	dynamic bool
+}
+
+// walk builds every state in the StateMachine and, for each of its
+// ValidIntents, calls Transition to discover where that intent leads.
+// Transition is called with a nil traverser since we only have static
+// access to the machine; implementations that panic on a nil
+// traverser are caught by recover and the edge is marked dynamic
+// rather than omitted.
+func walk(sm fsm.StateMachine) (map[string]*fsm.State, map[string][]edge) {
+	stateMap := fsm.GetStateMap(sm)
+
+	states := make(map[string]*fsm.State, len(stateMap))
+	edges := make(map[string][]edge, len(stateMap))
+
+	for slug, buildState := range stateMap {
+		states[slug] = buildState(nil, nil)
+	}
+
+	for slug, state := range states {
+		if state.ValidIntents == nil || state.Transition == nil {
+			// terminal/sink states commonly leave these nil; with no
+			// valid intents there are no edges to discover.
+			continue
+		}
+		for _, intent := range state.ValidIntents() {
+			edges[slug] = append(edges[slug], resolveEdge(state, intent))
+		}
+	}
+
+	return states, edges
+}
+
+func resolveEdge(state *fsm.State, intent *fsm.Intent) (e edge) {
+	e.intent = intent.Name
+
+	defer func() {
+		if r := recover(); r != nil {
+			e.dynamic = true
+			e.target = state.Slug
+		}
+	}()
+
+	newState := state.Transition(intent, nil)
+	if newState == nil {
+		// nil return is a self-loop: the intent is valid but doesn't
+		// move the traverser.
+		e.target = state.Slug
+		return e
+	}
+
+	e.target = newState.Slug
+	return e
+}
+
+// sortedSlugs returns the slugs of states in a deterministic order so
+// repeated renders of the same StateMachine produce identical output.
+func sortedSlugs(states map[string]*fsm.State) []string {
+	slugs := make([]string, 0, len(states))
+	for slug := range states {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+// RenderMermaid renders sm as a Mermaid flowchart. Non-exitable
+// states are drawn with a double border, StartState gets a distinct
+// style, and edges discovered via a panicking Transition are labeled
+// "dynamic" since their real target can't be determined statically.
+func RenderMermaid(sm fsm.StateMachine) (string, error) {
+	states, edges := walk(sm)
+	slugs := sortedSlugs(states)
+
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	for _, slug := range slugs {
+		state := states[slug]
+		if !state.IsExitable {
+			fmt.Fprintf(&b, "    %s[[%s]]\n", slug, slug)
+		} else {
+			fmt.Fprintf(&b, "    %s(%s)\n", slug, slug)
+		}
+		if slug == fsm.StartState {
+			fmt.Fprintf(&b, "    style %s fill:#9f9,stroke:#393\n", slug)
+		}
+	}
+
+	for _, slug := range slugs {
+		for _, e := range edges[slug] {
+			label := e.intent
+			if e.dynamic {
+				label = fmt.Sprintf("%s (dynamic)", label)
+			}
+			fmt.Fprintf(&b, "    %s -->|%s| %s\n", slug, label, e.target)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// RenderDOT renders sm as Graphviz DOT. Non-exitable states use
+// shape=doublecircle, StartState gets a distinct fill, and edges
+// discovered via a panicking Transition are labeled "dynamic" since
+// their real target can't be determined statically.
+func RenderDOT(sm fsm.StateMachine) (string, error) {
+	states, edges := walk(sm)
+	slugs := sortedSlugs(states)
+
+	var b strings.Builder
+	b.WriteString("digraph fsm {\n")
+
+	for _, slug := range slugs {
+		state := states[slug]
+		shape := "circle"
+		if !state.IsExitable {
+			shape = "doublecircle"
+		}
+		if slug == fsm.StartState {
+			fmt.Fprintf(&b, "    %q [shape=%s, style=filled, fillcolor=lightgreen];\n", slug, shape)
+		} else {
+			fmt.Fprintf(&b, "    %q [shape=%s];\n", slug, shape)
+		}
+	}
+
+	for _, slug := range slugs {
+		for _, e := range edges[slug] {
+			label := e.intent
+			if e.dynamic {
+				label = fmt.Sprintf("%s (dynamic)", label)
+			}
+			fmt.Fprintf(&b, "    %q -> %q [label=%q];\n", slug, e.target, label)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String(), nil
+}
@@ -0,0 +1,76 @@
+package stateparser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mindstand/fsm"
+)
+
+func buildStateStub(slug string, isExitable bool, validIntents func() []*fsm.Intent, transition func(*fsm.Intent, map[string]string) *fsm.State) fsm.BuildState {
+	return func(fsm.Emitter, fsm.Traverser) *fsm.State {
+		return &fsm.State{
+			Slug:         slug,
+			IsExitable:   isExitable,
+			ValidIntents: validIntents,
+			Transition:   transition,
+		}
+	}
+}
+
+func TestRenderMermaidIncludesStatesAndEdges(t *testing.T) {
+	intent := &fsm.Intent{Name: "go"}
+	validIntents := func() []*fsm.Intent { return []*fsm.Intent{intent} }
+
+	var endSlug string
+	sm := fsm.StateMachine{
+		buildStateStub(fsm.StartState, true, validIntents, func(*fsm.Intent, map[string]string) *fsm.State {
+			return &fsm.State{Slug: endSlug}
+		}),
+		buildStateStub("end", false, nil, nil),
+	}
+	endSlug = "end"
+
+	out, err := RenderMermaid(sm)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if !strings.Contains(out, "start") || !strings.Contains(out, "end") {
+		t.Fatalf("expected both states in output, got %q", out)
+	}
+	if !strings.Contains(out, "start -->|go| end") {
+		t.Fatalf("expected edge start -->|go| end, got %q", out)
+	}
+}
+
+func TestRenderDOTTerminalStateWithNilValidIntentsDoesNotPanic(t *testing.T) {
+	sm := fsm.StateMachine{
+		buildStateStub(fsm.StartState, true, nil, nil),
+	}
+
+	out, err := RenderDOT(sm)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if !strings.Contains(out, "start") {
+		t.Fatalf("expected start state in output, got %q", out)
+	}
+}
+
+func TestResolveEdgePanicIsMarkedDynamic(t *testing.T) {
+	state := &fsm.State{
+		Slug: "a",
+		Transition: func(*fsm.Intent, map[string]string) *fsm.State {
+			panic("needs a real traverser")
+		},
+	}
+	intent := &fsm.Intent{Name: "go"}
+
+	e := resolveEdge(state, intent)
+	if !e.dynamic {
+		t.Fatal("expected a panicking Transition to be marked dynamic")
+	}
+	if e.target != "a" {
+		t.Fatalf("expected dynamic edge to self-loop, got target %q", e.target)
+	}
+}
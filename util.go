@@ -1,6 +1,7 @@
 package fsm
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -20,9 +21,19 @@ func GetStateMap(stateMachine StateMachine) StateMap {
 	return stateMap
 }
 
-func TriggerState(platform, uuid, targetState string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap) error {
+// TriggerState is TriggerStateContext with a background context, for
+// callers that don't need cancellation.
+func TriggerState(platform, uuid, targetState string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
+	return TriggerStateContext(context.Background(), platform, uuid, targetState, input, InputTransformer, store, emitter, stateMap, policy)
+}
+
+// TriggerStateContext observes ctx.Done() while fetching/creating the
+// traverser and between each step of the transition, returning
+// ctx.Err() promptly instead of continuing once the caller has given
+// up.
+func TriggerStateContext(ctx context.Context, platform, uuid, targetState string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
 	// Get Traverser
-	traverser, _, err := getTraverser(platform, uuid, store)
+	traverser, _, err := getTraverserCtx(ctx, platform, uuid, store)
 	if err != nil {
 		return fmt.Errorf("traverser with id (%s) not found, %w", uuid, err)
 	}
@@ -45,27 +56,32 @@ func TriggerState(platform, uuid, targetState string, input interface{}, InputTr
 			return fmt.Errorf("failed to enqueue state, %w", err)
 		}
 
+		depth, _ := traverser.QueuedStateDepth()
+
+		notify(NotificationEvent{
+			UUID:        uuid,
+			Platform:    platform,
+			SourceState: curState,
+			TargetState: targetState,
+			EventType:   EventQueued,
+			Depth:       depth,
+		})
+
 		// cant go any further
 		return nil
 	}
 
-	lastUpdate, err := traverser.GetLastUpdateTime()
+	// serialize against any other Step/TriggerState in flight for this
+	// uuid, instead of guessing based on lastUpdate + InputTimeout
+	locker := lockerFor(store)
+	err = locker.LockContext(ctx, uuid)
 	if err != nil {
-		return fmt.Errorf("failed to get last update time, %w", err)
+		return fmt.Errorf("failed to acquire lock for uuid (%s), %w", uuid, err)
 	}
+	defer locker.Unlock(uuid)
 
-	// check if lastUpdate was even set
-	if !lastUpdate.IsZero() {
-		// check if its past the timeout
-		if !time.Now().UTC().After(lastUpdate.Add(InputTimeout)) {
-			// we have to queue it because another state is already in progress
-			err = traverser.AddQueuedState(targetState, input)
-			if err != nil {
-				return fmt.Errorf("failed to enqueue state, %w", err)
-			}
-			// we cant go any further
-			return nil
-		}
+	if err := ctx.Err(); err != nil {
+		return err
 	}
 
 	// we can actually handle the state now
@@ -74,17 +90,6 @@ func TriggerState(platform, uuid, targetState string, input interface{}, InputTr
 		return fmt.Errorf("state (%s) does not exist", targetState)
 	}
 
-	// set the current state in the traverser
-	err = traverser.SetCurrentState(targetState)
-	if err != nil {
-		return fmt.Errorf("failed to set target state, %w", err)
-	}
-
-	err = traverser.SetLastUpdateTime(time.Now().UTC())
-	if err != nil {
-		return fmt.Errorf("failed to set last updated time, %w", err)
-	}
-
 	// set info key
 	err = traverser.Upsert(QueueInfoKey, input)
 	if err != nil {
@@ -92,13 +97,14 @@ func TriggerState(platform, uuid, targetState string, input interface{}, InputTr
 	}
 
 	// now that we know that's a valid state we can set it in the traverser
-	currentState := stateObj(emitter, traverser)
-	err = performEntryAction(currentState, emitter, traverser, stateMap)
+	fromState := stateMap[curState](emitter, traverser)
+	targetStateObj := stateObj(emitter, traverser)
+	err = enterState(ctx, fromState, targetStateObj, emitter, traverser, stateMap, policy, nil, false)
 	if err != nil {
 		return fmt.Errorf("failed to perform entry action triggered state, %w", err)
 	}
 
-	return nil
+	return drainQueued(ctx, platform, uuid, store, emitter, stateMap, policy, traverser)
 }
 
 func checkStateExitable(state string, stateMap StateMap) (isExitable bool, ok bool) {
@@ -110,18 +116,40 @@ func checkStateExitable(state string, stateMap StateMap) (isExitable bool, ok bo
 	return stateObj(nil, nil).IsExitable, true
 }
 
-// Step performs a single step through a StateMachine.
+// Step is StepContext with a background context, for callers that
+// don't need cancellation.
+func Step(platform, uuid string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
+	return StepContext(context.Background(), platform, uuid, input, InputTransformer, store, emitter, stateMap, policy)
+}
+
+// StepContext performs a single step through a StateMachine.
 //
 // This function handles the nuance of the logic for a single step through a state machine.
-// ALL fsm-target's should call Step directly, and not attempt to handle the process of stepping through
-// the StateMachine, so all platforms function with the same logic.
-func Step(platform, uuid string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap) error {
+// ALL fsm-target's should call StepContext (or Step) directly, and not attempt to handle the
+// process of stepping through the StateMachine, so all platforms function with the same logic.
+//
+// It observes ctx.Done() while fetching/creating the traverser and between each step of the
+// transition, returning ctx.Err() promptly instead of continuing once the caller has given up.
+func StepContext(ctx context.Context, platform, uuid string, input interface{}, InputTransformer InputTransformer, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
 	// Get Traverser
-	traverser, newTraverser, err := getTraverser(platform, uuid, store)
+	traverser, newTraverser, err := getTraverserCtx(ctx, platform, uuid, store)
 	if err != nil {
 		return fmt.Errorf("traverser with id (%s) not found, %w", uuid, err)
 	}
 
+	// serialize against any other Step/TriggerState in flight for this
+	// uuid, instead of guessing based on lastUpdate + InputTimeout
+	locker := lockerFor(store)
+	err = locker.LockContext(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock for uuid (%s), %w", uuid, err)
+	}
+	defer locker.Unlock(uuid)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	// Get current state
 	traverserCurState, err := traverser.CurrentState()
 	if err != nil {
@@ -135,7 +163,7 @@ func Step(platform, uuid string, input interface{}, InputTransformer InputTransf
 
 	currentState := stateObj(emitter, traverser)
 	if newTraverser {
-		err = performEntryAction(currentState, emitter, traverser, stateMap)
+		err = performEntryAction(ctx, currentState, emitter, traverser, stateMap, policy)
 		if err != nil {
 			return fmt.Errorf("failed to perform action entry, %w", err)
 		}
@@ -146,39 +174,239 @@ func Step(platform, uuid string, input interface{}, InputTransformer InputTransf
 	if intent != nil {
 		newState := currentState.Transition(intent, params)
 		if newState != nil {
-			err = traverser.SetCurrentState(newState.Slug)
-			if err != nil {
-				return fmt.Errorf("failed to set current state during transition, %w", err)
-			}
-			err = traverser.SetLastUpdateTime(time.Now().UTC())
-			if err != nil {
-				return fmt.Errorf("failed to set last update time, %w", err)
-			}
-			err = performEntryAction(newState, emitter, traverser, stateMap)
+			err = enterState(ctx, currentState, newState, emitter, traverser, stateMap, policy, params, false)
 			if err != nil {
 				return fmt.Errorf("failed to perform action entry during transition, %w", err)
 			}
 		} else {
-			err = currentState.Entry(true)
-			if err != nil {
-				return fmt.Errorf("failed to enter current state, %w", err)
+			duration, entryErr := callEntry(ctx, currentState, true)
+			if entryErr != nil {
+				notify(NotificationEvent{
+					UUID:        uuid,
+					Platform:    platform,
+					SourceState: traverserCurState,
+					TargetState: traverserCurState,
+					EventType:   EventError,
+					Error:       entryErr,
+					Duration:    duration,
+				})
+				return fmt.Errorf("failed to enter current state, %w", entryErr)
 			}
+			notify(NotificationEvent{
+				UUID:        uuid,
+				Platform:    platform,
+				SourceState: traverserCurState,
+				TargetState: traverserCurState,
+				EventType:   EventReentry,
+				Duration:    duration,
+			})
 		}
 	} else {
-		err = currentState.Entry(true)
+		duration, entryErr := callEntry(ctx, currentState, true)
+		if entryErr != nil {
+			notify(NotificationEvent{
+				UUID:        uuid,
+				Platform:    platform,
+				SourceState: traverserCurState,
+				TargetState: traverserCurState,
+				EventType:   EventError,
+				Error:       entryErr,
+				Duration:    duration,
+			})
+			return fmt.Errorf("failed to enter current state, %w", entryErr)
+		}
+		notify(NotificationEvent{
+			UUID:        uuid,
+			Platform:    platform,
+			SourceState: traverserCurState,
+			TargetState: traverserCurState,
+			EventType:   EventReentry,
+			Duration:    duration,
+		})
+	}
+
+	return drainQueued(ctx, platform, uuid, store, emitter, stateMap, policy, traverser)
+}
+
+// drainQueued pops and triggers queued states for traverser, one at a
+// time, until the queue is empty, the current state still can't exit,
+// or a transition fails. It assumes the caller already holds the lock
+// for uuid, so it calls the transition logic directly rather than
+// going back through TriggerState (which would try to re-acquire it).
+//
+// A queued state is only ever removed for good once its transition
+// actually commits: if it turns out to reference a state no longer in
+// stateMap, or enterState rejects it, it's put back exactly like the
+// not-yet-exitable case already did, so it isn't silently lost out
+// from under a caller that had no reason to expect this drain to fail.
+func drainQueued(ctx context.Context, platform, uuid string, store Store, emitter Emitter, stateMap StateMap, policy Policy, traverser Traverser) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		queuedState, queuedInfo, err := traverser.PeekQueuedState()
+		if err != nil {
+			return fmt.Errorf("failed to peek queued state, %w", err)
+		}
+
+		if queuedState == "" {
+			return nil
+		}
+
+		err = traverser.DequeueQueuedState()
+		if err != nil {
+			return fmt.Errorf("failed to dequeue state, %w", err)
+		}
+
+		curState, err := traverser.CurrentState()
 		if err != nil {
-			return fmt.Errorf("failed to enter current state, %w", err)
+			if reErr := traverser.AddQueuedState(queuedState, queuedInfo); reErr != nil {
+				return fmt.Errorf("failed to get current state from traverser, %w, and failed to re-enqueue queued state, %v", err, reErr)
+			}
+			return fmt.Errorf("failed to get current state from traverser, %w", err)
+		}
+
+		canExit, ok := checkStateExitable(curState, stateMap)
+		if !ok {
+			if reErr := traverser.AddQueuedState(queuedState, queuedInfo); reErr != nil {
+				return fmt.Errorf("state (%s) does not exist, and failed to re-enqueue queued state, %w", curState, reErr)
+			}
+			return fmt.Errorf("state (%s) does not exist", curState)
+		}
+		if !canExit {
+			// still can't exit; put it back and let the next drain retry
+			return traverser.AddQueuedState(queuedState, queuedInfo)
+		}
+
+		stateObj, ok := stateMap[queuedState]
+		if !ok {
+			if reErr := traverser.AddQueuedState(queuedState, queuedInfo); reErr != nil {
+				return fmt.Errorf("state (%s) does not exist, and failed to re-enqueue it, %w", queuedState, reErr)
+			}
+			return fmt.Errorf("state (%s) does not exist", queuedState)
+		}
+
+		err = traverser.Upsert(QueueInfoKey, queuedInfo)
+		if err != nil {
+			if reErr := traverser.AddQueuedState(queuedState, queuedInfo); reErr != nil {
+				return fmt.Errorf("failed to upsert queue info, %w, and failed to re-enqueue queued state, %v", err, reErr)
+			}
+			return fmt.Errorf("failed to upsert queue info, %w", err)
+		}
+
+		fromState := stateMap[curState](emitter, traverser)
+		targetStateObj := stateObj(emitter, traverser)
+		err = enterState(ctx, fromState, targetStateObj, emitter, traverser, stateMap, policy, nil, false)
+		if err != nil {
+			if reErr := traverser.AddQueuedState(queuedState, queuedInfo); reErr != nil {
+				return fmt.Errorf("failed to perform entry action for queued state, %w, and failed to re-enqueue it, %v", err, reErr)
+			}
+			return fmt.Errorf("failed to perform entry action for queued state, %w", err)
 		}
 	}
+}
 
-	return nil
+// ProcessQueued drains a traverser's queued state, if one is waiting,
+// by triggering it. Step and TriggerState already do this
+// automatically once their own transition completes; call this
+// directly to retry a queue that's been sitting since a prior
+// attempt, e.g. on a schedule.
+func ProcessQueued(platform, uuid string, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
+	ctx := context.Background()
+
+	traverser, _, err := getTraverserCtx(ctx, platform, uuid, store)
+	if err != nil {
+		return fmt.Errorf("traverser with id (%s) not found, %w", uuid, err)
+	}
+
+	locker := lockerFor(store)
+	err = locker.LockContext(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock for uuid (%s), %w", uuid, err)
+	}
+	defer locker.Unlock(uuid)
+
+	return drainQueued(ctx, platform, uuid, store, emitter, stateMap, policy, traverser)
+}
+
+// Cancel forces a traverser stuck past InputTimeout into target,
+// which must be declared in policy.ForcedStates so the transition is
+// always permitted. Cancel takes the same lock Step/TriggerState use,
+// blocking until any transition still in flight for uuid releases it,
+// so it never runs alongside one; a hung Entry action that keeps
+// holding the lock blocks Cancel as well (cancel that action's
+// context instead). Once it holds the lock, Cancel refuses a traverser
+// whose last update is still within InputTimeout.
+func Cancel(platform, uuid, target string, store Store, emitter Emitter, stateMap StateMap, policy Policy) error {
+	ctx := context.Background()
+
+	if _, forced := policy.ForcedStates[target]; !forced {
+		return fmt.Errorf("cancel target (%s) is not declared in policy.ForcedStates", target)
+	}
+
+	traverser, _, err := getTraverserCtx(ctx, platform, uuid, store)
+	if err != nil {
+		return fmt.Errorf("traverser with id (%s) not found, %w", uuid, err)
+	}
+
+	locker := lockerFor(store)
+	err = locker.LockContext(ctx, uuid)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock for uuid (%s), %w", uuid, err)
+	}
+	defer locker.Unlock(uuid)
+
+	lastUpdate, err := traverser.GetLastUpdateTime()
+	if err != nil {
+		return fmt.Errorf("failed to get last update time, %w", err)
+	}
+	if !lastUpdate.IsZero() && !time.Now().UTC().After(lastUpdate.Add(InputTimeout)) {
+		return fmt.Errorf("traverser (%s) has not passed InputTimeout, refusing to cancel", uuid)
+	}
+
+	curState, err := traverser.CurrentState()
+	if err != nil {
+		return fmt.Errorf("failed to get current state from traverser, %w", err)
+	}
+
+	targetObj, ok := stateMap[target]
+	if !ok {
+		return fmt.Errorf("state (%s) does not exist", target)
+	}
+
+	fromBuildState, ok := stateMap[curState]
+	if !ok {
+		return fmt.Errorf("state (%s) does not exist", curState)
+	}
+	fromState := fromBuildState(emitter, traverser)
+
+	notifyTraverser(traverser, NotificationEvent{
+		SourceState: curState,
+		TargetState: target,
+		EventType:   EventTimeoutExpired,
+	})
+
+	return enterState(ctx, fromState, targetObj(emitter, traverser), emitter, traverser, stateMap, policy, nil, false)
 }
 
-func getTraverser(platform, uuid string, store Store) (Traverser, bool, error) {
+// getTraverserCtx fetches uuid's traverser, creating and seeding a new
+// one at StartState if it doesn't exist yet. It observes ctx.Done()
+// around the Fetch/Create calls, which may be implemented as blocking
+// I/O against a Store.
+func getTraverserCtx(ctx context.Context, platform, uuid string, store Store) (Traverser, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
 	newTraverser := false
-	traverser, err := store.FetchTraverser(uuid)
+	traverser, err := fetchTraverserCtx(ctx, store, uuid)
 	if err != nil {
-		traverser, err = store.CreateTraverser(uuid)
+		if err := ctx.Err(); err != nil {
+			return nil, false, err
+		}
+
+		traverser, err = createTraverserCtx(ctx, store, uuid)
 		if err != nil {
 			return nil, false, fmt.Errorf("failed to create traverser for id (%s), %w", uuid, err)
 		}
@@ -203,14 +431,67 @@ func getTraverser(platform, uuid string, store Store) (Traverser, bool, error) {
 	return traverser, newTraverser, nil
 }
 
+// fetchTraverserCtx calls store's context-aware FetchTraverserCtx if
+// it implements StoreCtx, falling back to the plain FetchTraverser.
+func fetchTraverserCtx(ctx context.Context, store Store, uuid string) (Traverser, error) {
+	if storeCtx, ok := store.(StoreCtx); ok {
+		return storeCtx.FetchTraverserCtx(ctx, uuid)
+	}
+	return store.FetchTraverser(uuid)
+}
+
+// createTraverserCtx calls store's context-aware CreateTraverserCtx if
+// it implements StoreCtx, falling back to the plain CreateTraverser.
+func createTraverserCtx(ctx context.Context, store Store, uuid string) (Traverser, error) {
+	if storeCtx, ok := store.(StoreCtx); ok {
+		return storeCtx.CreateTraverserCtx(ctx, uuid)
+	}
+	return store.CreateTraverser(uuid)
+}
+
+// callEntry invokes state's EntryFunc if set, falling back to the
+// legacy, context-oblivious Entry for states that haven't migrated. It
+// times the call so callers can attach how long it took to the
+// EventEntry/EventReentry/EventError notification they fire for it.
+func callEntry(ctx context.Context, state *State, isReentry bool) (time.Duration, error) {
+	start := time.Now()
+	if state.EntryFunc != nil {
+		err := state.EntryFunc(ctx, isReentry)
+		return time.Since(start), err
+	}
+	err := state.Entry(isReentry)
+	return time.Since(start), err
+}
+
 // performEntryAction handles the logic of switching states and calling the Entry function.
 //
 // It is handled via this function, as a state can manually switch states in the Entry function.
 // If that occurs, we then perform the Entry function of that state.  This continues until we land
 // in a state whose Entry action doesn't shift us to a new state.
-func performEntryAction(state *State, emitter Emitter, traverser Traverser, stateMap StateMap) error {
-	err := state.Entry(false)
+func performEntryAction(ctx context.Context, state *State, emitter Emitter, traverser Traverser, stateMap StateMap, policy Policy) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	duration, err := callEntry(ctx, state, false)
 	if err != nil {
+		notifyTraverser(traverser, NotificationEvent{
+			SourceState: state.Slug,
+			TargetState: state.Slug,
+			EventType:   EventError,
+			Error:       fmt.Errorf("failed to run entry action for state (%s), %w", state.Slug, err),
+			Duration:    duration,
+		})
+		return err
+	}
+	notifyTraverser(traverser, NotificationEvent{
+		SourceState: state.Slug,
+		TargetState: state.Slug,
+		EventType:   EventEntry,
+		Duration:    duration,
+	})
+
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
@@ -227,10 +508,205 @@ func performEntryAction(state *State, emitter Emitter, traverser Traverser, stat
 			return fmt.Errorf("state (%s) does not exist", currentState)
 		}
 		shiftedState := shift(emitter, traverser)
-		err = performEntryAction(shiftedState, emitter, traverser, stateMap)
+		notifyTraverser(traverser, NotificationEvent{
+			SourceState: state.Slug,
+			TargetState: shiftedState.Slug,
+			EventType:   EventReentry,
+		})
+		err = enterState(ctx, state, shiftedState, emitter, traverser, stateMap, policy, nil, false)
 		if err != nil {
 			return fmt.Errorf("failed to perform recursive entry action, %w", err)
 		}
 	}
 	return nil
 }
+
+// enterState is the single chokepoint for moving a traverser from one
+// state to another. It validates the transition against policy, fires
+// Exit on the outgoing state, updates the traverser's current state
+// and last-update time, and then fires Entry (via performEntryAction,
+// so further shifts made from within Entry are followed) on the
+// target state.
+//
+// It reads the traverser's Clock before doing any of that work and
+// re-reads it right before committing SetCurrentState, so a traverser
+// that moved underneath us while we were running a slow
+// Exit/OnTransition (e.g. a Store whose Locker doesn't actually
+// serialize across processes) is caught instead of silently
+// overwritten. The first read happens after the caller already holds
+// the lock, so a move made while waiting for the lock isn't detected.
+func enterState(ctx context.Context, fromState, toState *State, emitter Emitter, traverser Traverser, stateMap StateMap, policy Policy, params map[string]string, isReentry bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	clock, err := traverser.Clock()
+	if err != nil {
+		return fmt.Errorf("failed to read traverser clock, %w", err)
+	}
+
+	err = validateTransition(policy, fromState.Slug, toState.Slug)
+	if err != nil {
+		notifyTraverser(traverser, NotificationEvent{
+			SourceState: fromState.Slug,
+			TargetState: toState.Slug,
+			EventType:   EventError,
+			Error:       err,
+		})
+		return err
+	}
+
+	if fromState.Exit != nil {
+		err = fromState.Exit()
+		if err != nil {
+			err = fmt.Errorf("failed to exit state (%s), %w", fromState.Slug, err)
+			notifyTraverser(traverser, NotificationEvent{
+				SourceState: fromState.Slug,
+				TargetState: toState.Slug,
+				EventType:   EventError,
+				Error:       err,
+			})
+			return err
+		}
+	}
+
+	if toState.OnTransition != nil {
+		err = toState.OnTransition(ctx, traverser, params)
+		if err != nil {
+			err = fmt.Errorf("failed to run on-transition hook for state (%s), %w", toState.Slug, err)
+			notifyTraverser(traverser, NotificationEvent{
+				SourceState: fromState.Slug,
+				TargetState: toState.Slug,
+				EventType:   EventError,
+				Error:       err,
+			})
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	currentClock, err := traverser.Clock()
+	if err != nil {
+		return fmt.Errorf("failed to read traverser clock, %w", err)
+	}
+	if currentClock != clock {
+		err = fmt.Errorf("traverser moved underneath this transition (clock %d -> %d), aborting %s -> %s", clock, currentClock, fromState.Slug, toState.Slug)
+		notifyTraverser(traverser, NotificationEvent{
+			SourceState: fromState.Slug,
+			TargetState: toState.Slug,
+			EventType:   EventError,
+			Error:       err,
+		})
+		return err
+	}
+
+	err = traverser.SetCurrentState(toState.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to set current state during transition, %w", err)
+	}
+
+	err = traverser.SetLastUpdateTime(time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to set last update time, %w", err)
+	}
+
+	notifyTraverser(traverser, NotificationEvent{
+		SourceState: fromState.Slug,
+		TargetState: toState.Slug,
+		EventType:   EventTransition,
+	})
+
+	if isReentry {
+		duration, err := callEntry(ctx, toState, true)
+		if err != nil {
+			notifyTraverser(traverser, NotificationEvent{
+				SourceState: fromState.Slug,
+				TargetState: toState.Slug,
+				EventType:   EventError,
+				Error:       err,
+				Duration:    duration,
+			})
+			return err
+		}
+		notifyTraverser(traverser, NotificationEvent{
+			SourceState: fromState.Slug,
+			TargetState: toState.Slug,
+			EventType:   EventReentry,
+			Duration:    duration,
+		})
+		return nil
+	}
+
+	return performEntryAction(ctx, toState, emitter, traverser, stateMap, policy)
+}
+
+// validateTransition checks a proposed transition against policy's
+// Transitions table. A nil Transitions leaves every transition
+// unrestricted. A target in policy.ForcedStates is always permitted,
+// regardless of the table.
+func validateTransition(policy Policy, fromSlug, toSlug string) error {
+	if policy.Transitions == nil {
+		return nil
+	}
+
+	if _, forced := policy.ForcedStates[toSlug]; forced {
+		return nil
+	}
+
+	allowed, ok := policy.Transitions[fromSlug]
+	if !ok {
+		return fmt.Errorf("no transitions declared from state (%s)", fromSlug)
+	}
+
+	if _, ok := allowed[toSlug]; !ok {
+		return fmt.Errorf("transition from (%s) to (%s) is not permitted", fromSlug, toSlug)
+	}
+
+	return nil
+}
+
+// Validate checks a StateMachine against policy's Transitions table:
+// every slug referenced by the table must exist in the StateMachine,
+// and every state not in policy.ForcedStates must be reachable from
+// StartState by following the table.
+func Validate(sm StateMachine, policy Policy) error {
+	stateMap := GetStateMap(sm)
+
+	for from, targets := range policy.Transitions {
+		if _, ok := stateMap[from]; !ok {
+			return fmt.Errorf("transitions table references unknown state (%s)", from)
+		}
+		for to := range targets {
+			if _, ok := stateMap[to]; !ok {
+				return fmt.Errorf("transitions table references unknown state (%s)", to)
+			}
+		}
+	}
+
+	reachable := map[string]struct{}{StartState: {}}
+	queue := []string{StartState}
+	for len(queue) > 0 {
+		slug := queue[0]
+		queue = queue[1:]
+		for to := range policy.Transitions[slug] {
+			if _, seen := reachable[to]; !seen {
+				reachable[to] = struct{}{}
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	for slug := range stateMap {
+		if _, forced := policy.ForcedStates[slug]; forced {
+			continue
+		}
+		if _, ok := reachable[slug]; !ok {
+			return fmt.Errorf("state (%s) is not reachable from %s", slug, StartState)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package fsm
+
+import "testing"
+
+func buildStateStub(slug string, isExitable bool) BuildState {
+	return func(Emitter, Traverser) *State {
+		return &State{Slug: slug, IsExitable: isExitable}
+	}
+}
+
+func TestValidateReachableWithTransitions(t *testing.T) {
+	sm := StateMachine{
+		buildStateStub(StartState, true),
+		buildStateStub("middle", true),
+		buildStateStub("end", false),
+	}
+	policy := Policy{
+		Transitions: map[string]map[string]struct{}{
+			StartState: {"middle": {}},
+			"middle":   {"end": {}},
+		},
+	}
+
+	if err := Validate(sm, policy); err != nil {
+		t.Fatalf("expected sm to validate, got %v", err)
+	}
+}
+
+func TestValidateUnreachableState(t *testing.T) {
+	sm := StateMachine{
+		buildStateStub(StartState, true),
+		buildStateStub("middle", true),
+		buildStateStub("orphan", true),
+	}
+	policy := Policy{
+		Transitions: map[string]map[string]struct{}{
+			StartState: {"middle": {}},
+		},
+	}
+
+	if err := Validate(sm, policy); err == nil {
+		t.Fatal("expected orphan to be reported unreachable")
+	}
+}
+
+func TestValidateForcedStateSkipsReachability(t *testing.T) {
+	sm := StateMachine{
+		buildStateStub(StartState, true),
+		buildStateStub("error", false),
+	}
+	policy := Policy{
+		Transitions:  map[string]map[string]struct{}{StartState: {}},
+		ForcedStates: map[string]struct{}{"error": {}},
+	}
+
+	if err := Validate(sm, policy); err != nil {
+		t.Fatalf("expected forced state to be exempt from reachability, got %v", err)
+	}
+}
+
+func TestValidateUnknownStateInTable(t *testing.T) {
+	sm := StateMachine{
+		buildStateStub(StartState, true),
+	}
+	policy := Policy{
+		Transitions: map[string]map[string]struct{}{
+			StartState: {"nonexistent": {}},
+		},
+	}
+
+	if err := Validate(sm, policy); err == nil {
+		t.Fatal("expected unknown target state to be reported")
+	}
+}
+
+func TestValidateTransitionNilTableUnrestricted(t *testing.T) {
+	if err := validateTransition(Policy{}, "a", "b"); err != nil {
+		t.Fatalf("expected nil Transitions to leave transitions unrestricted, got %v", err)
+	}
+}
+
+func TestValidateTransitionForcedStateBypassesTable(t *testing.T) {
+	policy := Policy{
+		Transitions:  map[string]map[string]struct{}{"a": {}},
+		ForcedStates: map[string]struct{}{"b": {}},
+	}
+	if err := validateTransition(policy, "a", "b"); err != nil {
+		t.Fatalf("expected forced state to bypass the table, got %v", err)
+	}
+}
+
+func TestValidateTransitionDisallowed(t *testing.T) {
+	policy := Policy{
+		Transitions: map[string]map[string]struct{}{"a": {"c": {}}},
+	}
+	if err := validateTransition(policy, "a", "b"); err == nil {
+		t.Fatal("expected transition not present in the table to be rejected")
+	}
+}